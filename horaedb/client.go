@@ -0,0 +1,260 @@
+package horaedb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Client is the entry point for writing points to and querying HoraeDB.
+type Client interface {
+	// Write sends a batch of points to the server.
+	Write(ctx context.Context, req WriteRequest) (WriteResponse, error)
+
+	// SQLQuery runs a SQL query against the server.
+	SQLQuery(ctx context.Context, req SQLQueryRequest) (SQLQueryResponse, error)
+
+	// WriteStream opens a PointStream for bulk, COPY-style writes to
+	// table, buffering and auto-flushing points instead of requiring a
+	// fully materialized []Point slice up front.
+	WriteStream(ctx context.Context, table string, opts ...StreamOption) (PointStream, error)
+
+	// RefreshSchema forces the client to refetch and re-cache table's
+	// schema, rather than waiting to do so lazily after a write hits a
+	// schema-mismatch error. Callers that run DDL outside of Write (for
+	// example the horaedb/migrate package) use this to keep the "first
+	// write after a DDL fails" quirk from leaking into their own flows.
+	RefreshSchema(ctx context.Context, table string) error
+}
+
+// WriteRequest is a batch of points to write, all destined for the same
+// table.
+type WriteRequest struct {
+	Points []Point
+
+	// AutoRetryOnSchemaMismatch makes Write catch a schema-mismatch error
+	// from the server, refresh the client's cached schema/route for the
+	// affected table, and transparently re-issue the batch, up to the
+	// client's configured retry count (see WithSchemaRefreshRetry). This
+	// removes the need to write the same batch twice after a DDL change,
+	// since the first write after a DDL is the one that discovers the
+	// schema is stale.
+	AutoRetryOnSchemaMismatch bool
+}
+
+// WriteResponse reports how many points were written successfully.
+type WriteResponse struct {
+	Success uint32
+	Failed  uint32
+
+	// PerPartition breaks Success/Failed down by partition, keyed by
+	// partition index, for tables written with partition fan-out. It is
+	// nil for unpartitioned tables.
+	PerPartition map[int]PartitionResult
+}
+
+const defaultSchemaRefreshRetries = 1
+
+// ClientOption configures a Client built by NewClient.
+type ClientOption func(*client)
+
+// WithSchemaRefreshRetry sets how many times Write retries a batch, after
+// refreshing the cached schema, when the server reports a schema
+// mismatch. It only takes effect for requests with
+// WriteRequest.AutoRetryOnSchemaMismatch set. The default is 1.
+func WithSchemaRefreshRetry(n int) ClientOption {
+	return func(c *client) {
+		c.schemaRefreshRetries = n
+	}
+}
+
+// NewClient builds a Client backed by the given Transport.
+func NewClient(transport Transport, opts ...ClientOption) Client {
+	c := &client{
+		transport:            transport,
+		schemaRefreshRetries: defaultSchemaRefreshRetries,
+		schemas:              make(map[string]TableSchema),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+type client struct {
+	transport            Transport
+	schemaRefreshRetries int
+
+	mu      sync.RWMutex
+	schemas map[string]TableSchema
+}
+
+func (c *client) SQLQuery(ctx context.Context, req SQLQueryRequest) (SQLQueryResponse, error) {
+	return c.transport.Query(ctx, req)
+}
+
+func (c *client) Write(ctx context.Context, req WriteRequest) (WriteResponse, error) {
+	if len(req.Points) == 0 {
+		return WriteResponse{}, nil
+	}
+
+	attempts := 1
+	if req.AutoRetryOnSchemaMismatch {
+		attempts += c.schemaRefreshRetries
+	}
+
+	// Known limitation: a retry re-issues every point in req.Points, not
+	// just the ones belonging to partitions that actually saw the
+	// schema-mismatch error. For a partitioned table, writeOnce's own
+	// partial failure can mean some partitions already succeeded on a
+	// prior attempt; retrying the whole batch duplicate-writes those
+	// partitions rather than resuming only the failed ones.
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		resp, err := c.writeOnce(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+
+		if !req.AutoRetryOnSchemaMismatch || !errors.Is(err, ErrSchemaMismatch) {
+			return WriteResponse{}, err
+		}
+
+		c.invalidateSchema(req.Points[0].Table())
+	}
+
+	return WriteResponse{}, lastErr
+}
+
+// writeOnce resolves (and lazily caches) the table's schema and writes the
+// batch, fanning it out across partitions in parallel when the table is
+// partitioned.
+func (c *client) writeOnce(ctx context.Context, req WriteRequest) (WriteResponse, error) {
+	table := req.Points[0].Table()
+	schema, err := c.schemaFor(ctx, table)
+	if err != nil {
+		return WriteResponse{}, err
+	}
+
+	if schema.Partition == nil || schema.Partition.Count <= 1 {
+		result, err := c.transport.WritePartition(ctx, table, 0, req.Points)
+		if err != nil {
+			return WriteResponse{}, err
+		}
+		return WriteResponse{Success: result.Success, Failed: result.Failed}, nil
+	}
+
+	groups, err := groupByPartition(schema.Partition, req.Points)
+	if err != nil {
+		return WriteResponse{}, err
+	}
+	return c.writePartitionsConcurrently(ctx, table, groups)
+}
+
+// maxConcurrentPartitionWrites bounds how many partitions a single Write
+// call fans out to at once.
+const maxConcurrentPartitionWrites = 8
+
+func (c *client) writePartitionsConcurrently(ctx context.Context, table string, groups map[int][]Point) (WriteResponse, error) {
+	type outcome struct {
+		partition int
+		result    PartitionResult
+		err       error
+	}
+
+	sem := make(chan struct{}, maxConcurrentPartitionWrites)
+	results := make(chan outcome, len(groups))
+
+	for partition, points := range groups {
+		partition, points := partition, points
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem }()
+			result, err := c.transport.WritePartition(ctx, table, partition, points)
+			results <- outcome{partition: partition, result: result, err: err}
+		}()
+	}
+
+	resp := WriteResponse{PerPartition: make(map[int]PartitionResult, len(groups))}
+	var errs []error
+	for i := 0; i < len(groups); i++ {
+		o := <-results
+		if o.err != nil {
+			errs = append(errs, fmt.Errorf("horaedb: partition %d: %w", o.partition, o.err))
+			continue
+		}
+		resp.PerPartition[o.partition] = o.result
+		resp.Success += o.result.Success
+		resp.Failed += o.result.Failed
+	}
+
+	if len(errs) > 0 {
+		// resp already holds Success/Failed/PerPartition for every
+		// partition that completed before the failing one(s) — return it
+		// alongside the error rather than discarding it, so a caller can
+		// still see which partitions succeeded in a partial failure.
+		return resp, errors.Join(errs...)
+	}
+	return resp, nil
+}
+
+func (c *client) schemaFor(ctx context.Context, table string) (TableSchema, error) {
+	c.mu.RLock()
+	schema, ok := c.schemas[table]
+	c.mu.RUnlock()
+	if ok {
+		return schema, nil
+	}
+
+	schema, err := c.fetchSchema(ctx, table)
+	if err != nil {
+		return TableSchema{}, err
+	}
+
+	c.mu.Lock()
+	c.schemas[table] = schema
+	c.mu.Unlock()
+
+	return schema, nil
+}
+
+// fetchSchema fetches a table's schema from the transport and, if the
+// transport didn't already report structured partition metadata, derives
+// it by parsing the table's CREATE TABLE statement.
+func (c *client) fetchSchema(ctx context.Context, table string) (TableSchema, error) {
+	schema, err := c.transport.FetchSchema(ctx, table)
+	if err != nil {
+		return TableSchema{}, err
+	}
+
+	if schema.Partition == nil && schema.CreateTableSQL != "" {
+		partition, err := ParsePartitionClause(schema.CreateTableSQL)
+		if err != nil {
+			return TableSchema{}, err
+		}
+		schema.Partition = partition
+	}
+
+	return schema, nil
+}
+
+func (c *client) invalidateSchema(table string) {
+	c.mu.Lock()
+	delete(c.schemas, table)
+	c.mu.Unlock()
+}
+
+func (c *client) RefreshSchema(ctx context.Context, table string) error {
+	schema, err := c.fetchSchema(ctx, table)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.schemas[table] = schema
+	c.mu.Unlock()
+
+	return nil
+}
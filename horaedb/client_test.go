@@ -0,0 +1,91 @@
+package horaedb
+
+import (
+	"context"
+	"testing"
+)
+
+// fakeTransport fails the first writeFailures writes it sees, counted
+// across all tables, with ErrSchemaMismatch, then succeeds.
+type fakeTransport struct {
+	writeFailures int
+	writesSeen    int
+	schema        TableSchema
+}
+
+func (f *fakeTransport) WritePartition(_ context.Context, _ string, _ int, points []Point) (PartitionResult, error) {
+	f.writesSeen++
+	if f.writesSeen <= f.writeFailures {
+		return PartitionResult{}, ErrSchemaMismatch
+	}
+	return PartitionResult{Success: uint32(len(points))}, nil
+}
+
+func (f *fakeTransport) Query(_ context.Context, _ SQLQueryRequest) (SQLQueryResponse, error) {
+	return SQLQueryResponse{}, nil
+}
+
+func (f *fakeTransport) FetchSchema(_ context.Context, table string) (TableSchema, error) {
+	f.schema.Table = table
+	return f.schema, nil
+}
+
+func newTestPoint(t *testing.T) Point {
+	t.Helper()
+	p, err := NewPointBuilder("t").
+		SetTimestamp(1).
+		AddField("value", NewInt64Value(1)).
+		Build()
+	if err != nil {
+		t.Fatalf("build point: %v", err)
+	}
+	return p
+}
+
+func TestWriteRetriesOnSchemaMismatch(t *testing.T) {
+	transport := &fakeTransport{writeFailures: 1}
+	c := NewClient(transport)
+
+	resp, err := c.Write(context.Background(), WriteRequest{
+		Points:                    []Point{newTestPoint(t)},
+		AutoRetryOnSchemaMismatch: true,
+	})
+	if err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if resp.Success != 1 {
+		t.Fatalf("expect 1 success, got %+v", resp)
+	}
+	if transport.writesSeen != 2 {
+		t.Fatalf("expect write to be retried exactly once, saw %d attempts", transport.writesSeen)
+	}
+}
+
+func TestWriteDoesNotRetryWithoutOptIn(t *testing.T) {
+	transport := &fakeTransport{writeFailures: 1}
+	c := NewClient(transport)
+
+	_, err := c.Write(context.Background(), WriteRequest{Points: []Point{newTestPoint(t)}})
+	if err == nil {
+		t.Fatal("expect write to fail without AutoRetryOnSchemaMismatch")
+	}
+	if transport.writesSeen != 1 {
+		t.Fatalf("expect exactly 1 attempt, saw %d", transport.writesSeen)
+	}
+}
+
+func TestWriteGivesUpAfterConfiguredRetries(t *testing.T) {
+	transport := &fakeTransport{writeFailures: 5}
+	c := NewClient(transport, WithSchemaRefreshRetry(2))
+
+	_, err := c.Write(context.Background(), WriteRequest{
+		Points:                    []Point{newTestPoint(t)},
+		AutoRetryOnSchemaMismatch: true,
+	})
+	if err == nil {
+		t.Fatal("expect write to eventually give up and return an error")
+	}
+	if transport.writesSeen != 3 {
+		t.Fatalf("expect 1 initial attempt + 2 retries = 3, saw %d", transport.writesSeen)
+	}
+}
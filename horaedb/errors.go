@@ -0,0 +1,9 @@
+package horaedb
+
+import "errors"
+
+// ErrSchemaMismatch is returned by a Transport when the server rejects a
+// write or query because the client's cached table schema is stale (for
+// example, right after an ALTER TABLE). Client.Write unwraps this error to
+// decide whether to refresh the cached schema and retry.
+var ErrSchemaMismatch = errors.New("horaedb: table schema is out of date")
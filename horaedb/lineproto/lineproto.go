@@ -0,0 +1,283 @@
+// Package lineproto parses InfluxDB line protocol
+// (`measurement,tag=..,tag=.. field=..,field=.. timestamp`) into
+// horaedb.Point values, so line-protocol producers like Telegraf can
+// write into HoraeDB without hand-building a PointBuilder per row.
+package lineproto
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/apache/incubator-horaedb-client-go/horaedb"
+)
+
+// Precision is the unit of a line's trailing timestamp. InfluxDB line
+// protocol defaults to nanoseconds.
+type Precision int
+
+const (
+	PrecisionNanosecond Precision = iota
+	PrecisionMicrosecond
+	PrecisionMillisecond
+	PrecisionSecond
+)
+
+func (p Precision) divisorToMillis() int64 {
+	switch p {
+	case PrecisionNanosecond:
+		return 1e6
+	case PrecisionMicrosecond:
+		return 1e3
+	case PrecisionMillisecond:
+		return 1
+	case PrecisionSecond:
+		// Handled as a multiplication below; returning a negative divisor
+		// would be confusing, so seconds are special-cased in toMillis.
+		return 0
+	default:
+		return 1e6
+	}
+}
+
+func (p Precision) toMillis(ts int64) int64 {
+	if p == PrecisionSecond {
+		return ts * 1e3
+	}
+	return ts / p.divisorToMillis()
+}
+
+type options struct {
+	precision Precision
+}
+
+// Option configures a Decoder or ParsePoints/Write call.
+type Option func(*options)
+
+// WithPrecision sets the unit of the timestamp trailing each line. The
+// default is PrecisionNanosecond, matching InfluxDB's own default.
+func WithPrecision(p Precision) Option {
+	return func(o *options) {
+		o.precision = p
+	}
+}
+
+// Decoder reads line-protocol text from a Reader and decodes it one point
+// at a time, so large inputs do not need to be materialized in memory.
+type Decoder struct {
+	scanner *bufio.Scanner
+	opts    options
+}
+
+// NewDecoder returns a Decoder reading lines from r.
+func NewDecoder(r io.Reader, opts ...Option) *Decoder {
+	d := &Decoder{scanner: bufio.NewScanner(r)}
+	d.scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for _, opt := range opts {
+		opt(&d.opts)
+	}
+	return d
+}
+
+// Next decodes and returns the next point, skipping blank lines and
+// comments (lines starting with '#'). It returns io.EOF once the
+// underlying reader is exhausted.
+func (d *Decoder) Next() (horaedb.Point, error) {
+	for d.scanner.Scan() {
+		line := strings.TrimSpace(d.scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		return parseLine(line, d.opts.precision)
+	}
+	if err := d.scanner.Err(); err != nil {
+		return horaedb.Point{}, err
+	}
+	return horaedb.Point{}, io.EOF
+}
+
+// ParsePoints decodes every line in r into a Point.
+func ParsePoints(r io.Reader, opts ...Option) ([]horaedb.Point, error) {
+	d := NewDecoder(r, opts...)
+	var points []horaedb.Point
+	for {
+		p, err := d.Next()
+		if err == io.EOF {
+			return points, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, p)
+	}
+}
+
+// Write parses every line in r and writes the resulting points to client,
+// in a single WriteRequest. The measurement of each line becomes its
+// point's table name, but Client.Write routes the whole request by the
+// first point's table: input whose lines name different measurements is
+// not split into one write per table.
+func Write(ctx context.Context, client horaedb.Client, r io.Reader, opts ...Option) (horaedb.WriteResponse, error) {
+	points, err := ParsePoints(r, opts...)
+	if err != nil {
+		return horaedb.WriteResponse{}, err
+	}
+	return client.Write(ctx, horaedb.WriteRequest{Points: points})
+}
+
+func parseLine(line string, precision Precision) (horaedb.Point, error) {
+	tokens, err := splitUnescaped(line, ' ')
+	if err != nil {
+		return horaedb.Point{}, fmt.Errorf("lineproto: %s: %w", line, err)
+	}
+	if len(tokens) < 2 || len(tokens) > 3 {
+		return horaedb.Point{}, fmt.Errorf("lineproto: malformed line: %s", line)
+	}
+
+	measurementAndTags, err := splitUnescaped(tokens[0], ',')
+	if err != nil {
+		return horaedb.Point{}, fmt.Errorf("lineproto: %s: %w", line, err)
+	}
+	if len(measurementAndTags) == 0 || measurementAndTags[0] == "" {
+		return horaedb.Point{}, fmt.Errorf("lineproto: missing measurement: %s", line)
+	}
+
+	builder := horaedb.NewPointBuilder(unescape(measurementAndTags[0]))
+
+	for _, pair := range measurementAndTags[1:] {
+		key, val, err := splitKeyValue(pair)
+		if err != nil {
+			return horaedb.Point{}, fmt.Errorf("lineproto: %s: %w", line, err)
+		}
+		builder.AddTag(unescape(key), horaedb.NewStringValue(unescape(val)))
+	}
+
+	fieldPairs, err := splitUnescaped(tokens[1], ',')
+	if err != nil {
+		return horaedb.Point{}, fmt.Errorf("lineproto: %s: %w", line, err)
+	}
+	if len(fieldPairs) == 0 {
+		return horaedb.Point{}, fmt.Errorf("lineproto: line has no fields: %s", line)
+	}
+	for _, pair := range fieldPairs {
+		key, raw, err := splitKeyValue(pair)
+		if err != nil {
+			return horaedb.Point{}, fmt.Errorf("lineproto: %s: %w", line, err)
+		}
+		fieldValue, err := parseFieldValue(raw)
+		if err != nil {
+			return horaedb.Point{}, fmt.Errorf("lineproto: field %q: %w", key, err)
+		}
+		builder.AddField(unescape(key), fieldValue)
+	}
+
+	if len(tokens) == 3 {
+		ts, err := strconv.ParseInt(tokens[2], 10, 64)
+		if err != nil {
+			return horaedb.Point{}, fmt.Errorf("lineproto: bad timestamp %q: %w", tokens[2], err)
+		}
+		builder.SetTimestamp(precision.toMillis(ts))
+	} else {
+		return horaedb.Point{}, fmt.Errorf("lineproto: line has no timestamp: %s", line)
+	}
+
+	return builder.Build()
+}
+
+func splitKeyValue(pair string) (key, val string, err error) {
+	parts, err := splitUnescaped(pair, '=')
+	if err != nil {
+		return "", "", err
+	}
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("malformed key=value pair: %s", pair)
+	}
+	return parts[0], parts[1], nil
+}
+
+// splitUnescaped splits s on sep, ignoring any sep that is
+// backslash-escaped or that falls inside a double-quoted string. A
+// backslash escapes the following character whether or not it appears
+// inside a quoted string, so a quoted field value can itself contain an
+// escaped quote (`\"`) without prematurely closing the string.
+func splitUnescaped(s string, sep byte) ([]string, error) {
+	var (
+		parts   []string
+		cur     strings.Builder
+		escaped bool
+		quoted  bool
+	)
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case escaped:
+			cur.WriteByte(c)
+			escaped = false
+		case c == '\\':
+			cur.WriteByte(c)
+			escaped = true
+		case c == '"':
+			cur.WriteByte(c)
+			quoted = !quoted
+		case c == sep && !quoted:
+			parts = append(parts, cur.String())
+			cur.Reset()
+		default:
+			cur.WriteByte(c)
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated quoted string")
+	}
+	parts = append(parts, cur.String())
+	return parts, nil
+}
+
+// unescape removes backslash escaping from commas, spaces, equals signs
+// and backslashes in measurement names, tag keys/values and field keys.
+func unescape(s string) string {
+	replacer := strings.NewReplacer(`\,`, `,`, `\ `, ` `, `\=`, `=`, `\\`, `\`)
+	return replacer.Replace(s)
+}
+
+// unquoteString strips the surrounding double quotes from a string field
+// value and unescapes \" and \\.
+func unquoteString(s string) string {
+	s = strings.TrimPrefix(s, `"`)
+	s = strings.TrimSuffix(s, `"`)
+	replacer := strings.NewReplacer(`\"`, `"`, `\\`, `\`)
+	return replacer.Replace(s)
+}
+
+func parseFieldValue(raw string) (horaedb.Value, error) {
+	switch {
+	case strings.HasPrefix(raw, `"`) && strings.HasSuffix(raw, `"`) && len(raw) >= 2:
+		return horaedb.NewStringValue(unquoteString(raw)), nil
+	case strings.HasSuffix(raw, "i"):
+		n, err := strconv.ParseInt(strings.TrimSuffix(raw, "i"), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return horaedb.NewInt64Value(n), nil
+	case strings.HasSuffix(raw, "u"):
+		n, err := strconv.ParseUint(strings.TrimSuffix(raw, "u"), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return horaedb.NewUint64Value(n), nil
+	case raw == "t" || raw == "T" || raw == "true" || raw == "True" || raw == "TRUE":
+		return horaedb.NewBoolValue(true), nil
+	case raw == "f" || raw == "F" || raw == "false" || raw == "False" || raw == "FALSE":
+		return horaedb.NewBoolValue(false), nil
+	default:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, err
+		}
+		return horaedb.NewFloat64Value(f), nil
+	}
+}
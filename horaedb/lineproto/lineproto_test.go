@@ -0,0 +1,89 @@
+package lineproto
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParsePointsEscapingAndTypes(t *testing.T) {
+	input := strings.Join([]string{
+		`cpu,host=server\,1,region=us-west idle=95.3,running=true,count=3i,big=7u 1690000000000000000`,
+		`cpu,host=server2 note="hello, world",idle=12i 1690000000001000000`,
+	}, "\n")
+
+	points, err := ParsePoints(strings.NewReader(input), WithPrecision(PrecisionNanosecond))
+	if err != nil {
+		t.Fatalf("ParsePoints: %v", err)
+	}
+	if len(points) != 2 {
+		t.Fatalf("expect 2 points, got %d", len(points))
+	}
+
+	p0 := points[0]
+	if p0.Table() != "cpu" {
+		t.Fatalf("expect table cpu, got %s", p0.Table())
+	}
+	if host, _ := p0.Tags()["host"].String(); host != "server,1" {
+		t.Fatalf("expect unescaped tag value 'server,1', got %q", host)
+	}
+	if region, _ := p0.Tags()["region"].String(); region != "us-west" {
+		t.Fatalf("expect region tag us-west, got %q", region)
+	}
+	if running, _ := p0.Fields()["running"].Bool(); !running {
+		t.Fatalf("expect running=true")
+	}
+	if count, _ := p0.Fields()["count"].Int64(); count != 3 {
+		t.Fatalf("expect count=3, got %d", count)
+	}
+	if big, _ := p0.Fields()["big"].Uint64(); big != 7 {
+		t.Fatalf("expect big=7, got %d", big)
+	}
+	if p0.Timestamp() != 1690000000000 {
+		t.Fatalf("expect timestamp converted to millis, got %d", p0.Timestamp())
+	}
+
+	p1 := points[1]
+	if note, _ := p1.Fields()["note"].String(); note != "hello, world" {
+		t.Fatalf("expect quoted field value with embedded comma, got %q", note)
+	}
+}
+
+func TestParsePointsMissingFieldsIsError(t *testing.T) {
+	_, err := ParsePoints(strings.NewReader("cpu,host=a 1690000000000000000"))
+	if err == nil {
+		t.Fatal("expect error for line with no fields")
+	}
+}
+
+func TestSplitUnescapedHonorsEscapedQuoteInsideQuotedString(t *testing.T) {
+	parts, err := splitUnescaped(`field="say \"hi\"" 123`, ' ')
+	if err != nil {
+		t.Fatalf("splitUnescaped: %v", err)
+	}
+	if len(parts) != 2 {
+		t.Fatalf("expect 2 parts, got %d: %v", len(parts), parts)
+	}
+	if parts[0] != `field="say \"hi\""` {
+		t.Fatalf("expect the quoted field to stay intact, got %q", parts[0])
+	}
+	if parts[1] != "123" {
+		t.Fatalf("expect the timestamp to split off, got %q", parts[1])
+	}
+}
+
+func TestParsePointsEscapedQuoteInsideQuotedFieldValue(t *testing.T) {
+	input := `cpu,host=a note="say \"hi\"" 1690000000000000000`
+
+	points, err := ParsePoints(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParsePoints: %v", err)
+	}
+	if len(points) != 1 {
+		t.Fatalf("expect 1 point, got %d", len(points))
+	}
+
+	note, _ := points[0].Fields()["note"].String()
+	if note != `say "hi"` {
+		t.Fatalf(`expect note to unescape to say "hi", got %q`, note)
+	}
+}
@@ -0,0 +1,43 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/incubator-horaedb-client-go/horaedb"
+)
+
+// AddColumn returns a Migration.Up (or Down) function that adds a column
+// to an existing table and refreshes the client's cached schema for it,
+// so the write immediately following a migration doesn't hit the usual
+// "first write after a DDL fails" schema-mismatch quirk.
+func AddColumn(table, name, typ string, tag bool) func(ctx context.Context, client horaedb.Client) error {
+	return func(ctx context.Context, client horaedb.Client) error {
+		columnDef := fmt.Sprintf("%s %s", name, typ)
+		if tag {
+			columnDef += " TAG"
+		}
+
+		sql := fmt.Sprintf("ALTER TABLE `%s` ADD COLUMN (%s);", table, columnDef)
+		if _, err := client.SQLQuery(ctx, horaedb.SQLQueryRequest{Tables: []string{table}, SQL: sql}); err != nil {
+			return err
+		}
+
+		return client.RefreshSchema(ctx, table)
+	}
+}
+
+// CreatePartitionedTable returns a Migration.Up function that creates a
+// table with the given column definitions (e.g. "`name` string TAG,
+// `value` int64 NOT NULL, `t` timestamp NOT NULL, TIMESTAMP KEY(t)"),
+// partitioned by KEY on partitionColumn.
+func CreatePartitionedTable(table, columnDefs, partitionColumn string, partitionCount int) func(ctx context.Context, client horaedb.Client) error {
+	return func(ctx context.Context, client horaedb.Client) error {
+		sql := fmt.Sprintf(
+			"CREATE TABLE `%s`(%s) PARTITION BY KEY(%s) PARTITIONS %d ENGINE = Analytic",
+			table, columnDefs, partitionColumn, partitionCount)
+
+		_, err := client.SQLQuery(ctx, horaedb.SQLQueryRequest{Tables: []string{table}, SQL: sql})
+		return err
+	}
+}
@@ -0,0 +1,105 @@
+// Package migrate is a small schema-migration runner for HoraeDB,
+// inspired by xormigrate. Migrations are registered with an ordered ID
+// and applied in ID order; the package tracks which ones have run in a
+// `horaedb_schema_migrations` table so Run can be called idempotently
+// (e.g. once per service start) without reapplying DDL that already
+// happened.
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/apache/incubator-horaedb-client-go/horaedb"
+)
+
+// TrackingTable is the table migrate uses to record which migrations
+// have been applied.
+const TrackingTable = "horaedb_schema_migrations"
+
+// Migration is a single, idempotent schema change.
+type Migration struct {
+	ID          string
+	Description string
+	Up          func(ctx context.Context, client horaedb.Client) error
+	Down        func(ctx context.Context, client horaedb.Client) error
+}
+
+// Run applies every migration in migrations whose ID is not yet recorded
+// as applied, in ascending ID order, and records each one as it
+// succeeds. Calling Run again with the same (or a superset of)
+// migrations only applies the new ones.
+func Run(ctx context.Context, client horaedb.Client, migrations []Migration) error {
+	if err := ensureTrackingTable(ctx, client); err != nil {
+		return fmt.Errorf("migrate: %w", err)
+	}
+
+	applied, err := appliedStates(ctx, client)
+	if err != nil {
+		return fmt.Errorf("migrate: %w", err)
+	}
+
+	pending := make([]Migration, len(migrations))
+	copy(pending, migrations)
+	sort.Slice(pending, func(i, j int) bool { return pending[i].ID < pending[j].ID })
+
+	for _, m := range pending {
+		if applied[m.ID] {
+			continue
+		}
+		if m.Up == nil {
+			return fmt.Errorf("migrate: migration %q has no Up", m.ID)
+		}
+		if err := m.Up(ctx, client); err != nil {
+			return fmt.Errorf("migrate: %s: %w", m.ID, err)
+		}
+		if err := recordMigration(ctx, client, m, "up"); err != nil {
+			return fmt.Errorf("migrate: %s: recording applied migration: %w", m.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// Rollback reverts the last n applied migrations, most recently applied
+// first, calling their Down functions. migrations must include the
+// Migration entries being rolled back, since their Down functions are
+// not stored in the tracking table.
+func Rollback(ctx context.Context, client horaedb.Client, migrations []Migration, n int) error {
+	if n <= 0 {
+		return nil
+	}
+
+	if err := ensureTrackingTable(ctx, client); err != nil {
+		return fmt.Errorf("migrate: %w", err)
+	}
+
+	recent, err := recentlyAppliedIDs(ctx, client, n)
+	if err != nil {
+		return fmt.Errorf("migrate: %w", err)
+	}
+
+	byID := make(map[string]Migration, len(migrations))
+	for _, m := range migrations {
+		byID[m.ID] = m
+	}
+
+	for _, id := range recent {
+		m, ok := byID[id]
+		if !ok {
+			return fmt.Errorf("migrate: rollback: migration %q was applied but is not registered", id)
+		}
+		if m.Down == nil {
+			return fmt.Errorf("migrate: rollback: migration %q has no Down", id)
+		}
+		if err := m.Down(ctx, client); err != nil {
+			return fmt.Errorf("migrate: rollback %s: %w", id, err)
+		}
+		if err := recordMigration(ctx, client, m, "down"); err != nil {
+			return fmt.Errorf("migrate: rollback %s: recording rollback: %w", id, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package migrate
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-horaedb-client-go/horaedb"
+)
+
+// fakeClient is an in-memory horaedb.Client good enough to exercise
+// migrate's tracking-table logic without a real server.
+type fakeClient struct {
+	points       []horaedb.Point
+	refreshedFor []string
+	executedDDL  []string
+}
+
+func (f *fakeClient) Write(_ context.Context, req horaedb.WriteRequest) (horaedb.WriteResponse, error) {
+	f.points = append(f.points, req.Points...)
+	return horaedb.WriteResponse{Success: uint32(len(req.Points))}, nil
+}
+
+func (f *fakeClient) SQLQuery(_ context.Context, req horaedb.SQLQueryRequest) (horaedb.SQLQueryResponse, error) {
+	if strings.HasPrefix(strings.ToLower(strings.TrimSpace(req.SQL)), "select") {
+		return f.queryTracking(), nil
+	}
+	f.executedDDL = append(f.executedDDL, req.SQL)
+	return horaedb.SQLQueryResponse{}, nil
+}
+
+// queryTracking returns one row per tracking-table point passed to Write, in the
+// order they were written — standing in for "order by t", since writes
+// in this fake already happen in chronological order.
+func (f *fakeClient) queryTracking() horaedb.SQLQueryResponse {
+	rows := make([]horaedb.Row, 0, len(f.points))
+	for _, p := range f.points {
+		if p.Table() != TrackingTable {
+			continue
+		}
+		id, _ := p.Tags()["id"].String()
+		action, _ := p.Fields()["action"].String()
+		rows = append(rows, horaedb.NewRow(
+			[]string{"id", "action", "t"},
+			[]horaedb.Value{
+				horaedb.NewStringValue(id),
+				horaedb.NewStringValue(action),
+				horaedb.NewInt64Value(p.Timestamp()),
+			}))
+	}
+	return horaedb.SQLQueryResponse{Rows: rows}
+}
+
+func (f *fakeClient) WriteStream(_ context.Context, _ string, _ ...horaedb.StreamOption) (horaedb.PointStream, error) {
+	panic("not used by migrate tests")
+}
+
+func (f *fakeClient) RefreshSchema(_ context.Context, table string) error {
+	f.refreshedFor = append(f.refreshedFor, table)
+	return nil
+}
+
+func TestRunAppliesPendingMigrationsInIDOrder(t *testing.T) {
+	client := &fakeClient{}
+	var order []string
+
+	migrations := []Migration{
+		{
+			ID: "2", Description: "second",
+			Up:   func(context.Context, horaedb.Client) error { order = append(order, "2"); return nil },
+			Down: func(context.Context, horaedb.Client) error { return nil },
+		},
+		{
+			ID: "1", Description: "first",
+			Up:   func(context.Context, horaedb.Client) error { order = append(order, "1"); return nil },
+			Down: func(context.Context, horaedb.Client) error { return nil },
+		},
+	}
+
+	if err := Run(context.Background(), client, migrations); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if strings.Join(order, ",") != "1,2" {
+		t.Fatalf("expect migrations applied in ID order, got %v", order)
+	}
+
+	// Running again must not re-apply anything.
+	order = nil
+	if err := Run(context.Background(), client, migrations); err != nil {
+		t.Fatalf("second Run: %v", err)
+	}
+	if len(order) != 0 {
+		t.Fatalf("expect no migrations re-applied, got %v", order)
+	}
+}
+
+func TestRollbackRevertsLastN(t *testing.T) {
+	client := &fakeClient{}
+	var reverted []string
+
+	migrations := []Migration{
+		{ID: "1", Up: noop, Down: func(context.Context, horaedb.Client) error { reverted = append(reverted, "1"); return nil }},
+		{ID: "2", Up: noop, Down: func(context.Context, horaedb.Client) error { reverted = append(reverted, "2"); return nil }},
+		{ID: "3", Up: noop, Down: func(context.Context, horaedb.Client) error { reverted = append(reverted, "3"); return nil }},
+	}
+
+	if err := Run(context.Background(), client, migrations); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if err := Rollback(context.Background(), client, migrations, 2); err != nil {
+		t.Fatalf("Rollback: %v", err)
+	}
+
+	if strings.Join(reverted, ",") != "3,2" {
+		t.Fatalf("expect last 2 migrations reverted most-recent-first, got %v", reverted)
+	}
+}
+
+func noop(context.Context, horaedb.Client) error { return nil }
@@ -0,0 +1,116 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-horaedb-client-go/horaedb"
+)
+
+func ensureTrackingTable(ctx context.Context, client horaedb.Client) error {
+	sql := fmt.Sprintf(
+		"CREATE TABLE IF NOT EXISTS `%s`("+
+			"`id` string TAG,"+
+			"`description` string NOT NULL,"+
+			"`action` string NOT NULL,"+
+			"`t` timestamp NOT NULL,"+
+			"TIMESTAMP KEY(t)) ENGINE = Analytic", TrackingTable)
+
+	_, err := client.SQLQuery(ctx, horaedb.SQLQueryRequest{
+		Tables: []string{TrackingTable},
+		SQL:    sql,
+	})
+	return err
+}
+
+// recordMigration appends a record of a migration being applied ("up")
+// or reverted ("down"). Records are append-only: the migration table
+// acts as an audit log, and a migration's current state is whichever
+// action was recorded most recently.
+func recordMigration(ctx context.Context, client horaedb.Client, m Migration, action string) error {
+	point, err := horaedb.NewPointBuilder(TrackingTable).
+		SetTimestamp(time.Now().UnixMilli()).
+		AddTag("id", horaedb.NewStringValue(m.ID)).
+		AddField("description", horaedb.NewStringValue(m.Description)).
+		AddField("action", horaedb.NewStringValue(action)).
+		Build()
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Write(ctx, horaedb.WriteRequest{Points: []horaedb.Point{point}})
+	return err
+}
+
+// appliedStates returns, for every migration ID ever recorded, whether
+// its most recent recorded action was "up".
+func appliedStates(ctx context.Context, client horaedb.Client) (map[string]bool, error) {
+	sql := fmt.Sprintf("select id, action, t from %s order by t", TrackingTable)
+
+	resp, err := client.SQLQuery(ctx, horaedb.SQLQueryRequest{
+		Tables: []string{TrackingTable},
+		SQL:    sql,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(map[string]bool)
+	for _, row := range resp.Rows {
+		cols := row.Columns()
+		if len(cols) < 2 {
+			continue
+		}
+		id, _ := cols[0].String()
+		action, _ := cols[1].String()
+		applied[id] = action == "up"
+	}
+	return applied, nil
+}
+
+// recentlyAppliedIDs returns the IDs of the last n migrations whose most
+// recent recorded action was "up", most recently applied first.
+func recentlyAppliedIDs(ctx context.Context, client horaedb.Client, n int) ([]string, error) {
+	sql := fmt.Sprintf("select id, action, t from %s order by t", TrackingTable)
+
+	resp, err := client.SQLQuery(ctx, horaedb.SQLQueryRequest{
+		Tables: []string{TrackingTable},
+		SQL:    sql,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Walk the append-only log in chronological (query) order, keeping
+	// each ID's most recent action and its position in that order. The
+	// position, not the raw timestamp, ranks recency: migrations applied
+	// in the same millisecond still have a well-defined relative order.
+	lastAction := make(map[string]string, len(resp.Rows))
+	lastRank := make(map[string]int, len(resp.Rows))
+	for i, row := range resp.Rows {
+		cols := row.Columns()
+		if len(cols) < 2 {
+			continue
+		}
+		id, _ := cols[0].String()
+		action, _ := cols[1].String()
+		lastAction[id] = action
+		lastRank[id] = i
+	}
+
+	applied := make([]string, 0, len(lastAction))
+	for id, action := range lastAction {
+		if action == "up" {
+			applied = append(applied, id)
+		}
+	}
+
+	sort.Slice(applied, func(i, j int) bool { return lastRank[applied[i]] > lastRank[applied[j]] })
+
+	if n < len(applied) {
+		applied = applied[:n]
+	}
+	return applied, nil
+}
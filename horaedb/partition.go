@@ -0,0 +1,150 @@
+package horaedb
+
+import (
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PartitionType identifies how a partitioned table distributes rows
+// across its partitions, mirroring HoraeDB's `PARTITION BY` clause.
+type PartitionType int
+
+const (
+	PartitionTypeKey PartitionType = iota
+	PartitionTypeHash
+	PartitionTypeRange
+)
+
+func (t PartitionType) String() string {
+	switch t {
+	case PartitionTypeKey:
+		return "KEY"
+	case PartitionTypeHash:
+		return "HASH"
+	case PartitionTypeRange:
+		return "RANGE"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// PartitionInfo describes a partitioned table's `PARTITION BY` clause.
+type PartitionInfo struct {
+	Type    PartitionType
+	Columns []string
+	Count   int
+}
+
+// PartitionResult is the outcome of writing a batch of points to a single
+// partition.
+type PartitionResult struct {
+	Success uint32
+	Failed  uint32
+}
+
+// partitionClauseRe matches the `PARTITION BY {KEY|HASH|RANGE}(col[,
+// col...]) PARTITIONS n` clause this repo's DDL uses, e.g.
+// "PARTITION BY KEY(name) PARTITIONS 4".
+var partitionClauseRe = regexp.MustCompile(`(?i)PARTITION\s+BY\s+(KEY|HASH|RANGE)\s*\(([^)]*)\)\s*PARTITIONS\s+(\d+)`)
+
+// ParsePartitionClause extracts the PARTITION BY clause from a CREATE
+// TABLE statement. It returns (nil, nil) if the statement has no such
+// clause (i.e. the table is not partitioned, or uses a RANGE-with-
+// explicit-boundaries form this parser does not yet understand).
+func ParsePartitionClause(createTableSQL string) (*PartitionInfo, error) {
+	m := partitionClauseRe.FindStringSubmatch(createTableSQL)
+	if m == nil {
+		return nil, nil
+	}
+
+	var ptype PartitionType
+	switch strings.ToUpper(m[1]) {
+	case "KEY":
+		ptype = PartitionTypeKey
+	case "HASH":
+		ptype = PartitionTypeHash
+	case "RANGE":
+		ptype = PartitionTypeRange
+	}
+
+	count, err := strconv.Atoi(m[3])
+	if err != nil {
+		return nil, fmt.Errorf("horaedb: invalid partition count in %q: %w", createTableSQL, err)
+	}
+
+	columns := strings.Split(m[2], ",")
+	for i, c := range columns {
+		columns[i] = strings.Trim(strings.TrimSpace(c), "`")
+	}
+
+	return &PartitionInfo{Type: ptype, Columns: columns, Count: count}, nil
+}
+
+// computePartition returns the index of the partition responsible for p,
+// for KEY and HASH partitioned tables: the partition key column values
+// are hashed (FNV-1a) and reduced modulo the partition count. This is
+// assumed, not verified, to match the server's own routing for these
+// partition types — nothing in this package has been checked against a
+// real HoraeDB cluster, so a mismatch here would silently fan writes out
+// to the wrong partition. Treat this as a follow-up risk to validate
+// before relying on it against a live server. RANGE partitioning needs
+// its boundary list, which this client does not parse yet, so it is
+// rejected rather than silently routed to the wrong place.
+func computePartition(info *PartitionInfo, p Point) (int, error) {
+	if info.Count <= 0 {
+		return 0, fmt.Errorf("horaedb: table %q has no partitions", p.Table())
+	}
+
+	switch info.Type {
+	case PartitionTypeKey, PartitionTypeHash:
+		h := fnv.New64a()
+		for _, col := range info.Columns {
+			v, ok := p.PartitionKey(col)
+			if !ok {
+				return 0, fmt.Errorf("horaedb: point for table %q is missing partition column %q", p.Table(), col)
+			}
+			writePartitionKey(h, v)
+		}
+		return int(h.Sum64() % uint64(info.Count)), nil
+	default:
+		return 0, fmt.Errorf("horaedb: client-side routing for %s partitioning is not supported yet", info.Type)
+	}
+}
+
+func writePartitionKey(h hash.Hash64, v Value) {
+	switch v.Kind() {
+	case KindInt64:
+		i, _ := v.Int64()
+		fmt.Fprintf(h, "%d", i)
+	case KindUint64:
+		u, _ := v.Uint64()
+		fmt.Fprintf(h, "%d", u)
+	case KindFloat64:
+		f, _ := v.Float64()
+		fmt.Fprintf(h, "%v", f)
+	case KindBool:
+		b, _ := v.Bool()
+		fmt.Fprintf(h, "%v", b)
+	default:
+		s, _ := v.String()
+		fmt.Fprint(h, s)
+	}
+}
+
+// groupByPartition buckets points by the partition responsible for each,
+// per info.
+func groupByPartition(info *PartitionInfo, points []Point) (map[int][]Point, error) {
+	groups := make(map[int][]Point)
+	for _, p := range points {
+		idx, err := computePartition(info, p)
+		if err != nil {
+			return nil, err
+		}
+		groups[idx] = append(groups[idx], p)
+	}
+	return groups, nil
+}
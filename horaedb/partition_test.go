@@ -0,0 +1,130 @@
+package horaedb
+
+import (
+	"context"
+	"strconv"
+	"testing"
+)
+
+func TestParsePartitionClause(t *testing.T) {
+	sql := "CREATE TABLE `partition_table`(`name`string TAG,`id` int TAG,`value` int64 NOT NULL," +
+		"`t` timestamp NOT NULL,TIMESTAMP KEY(t)) PARTITION BY KEY(name) PARTITIONS 4 ENGINE = Analytic"
+
+	info, err := ParsePartitionClause(sql)
+	if err != nil {
+		t.Fatalf("ParsePartitionClause: %v", err)
+	}
+	if info == nil {
+		t.Fatal("expect a non-nil PartitionInfo")
+	}
+	if info.Type != PartitionTypeKey {
+		t.Fatalf("expect KEY partitioning, got %s", info.Type)
+	}
+	if info.Count != 4 {
+		t.Fatalf("expect 4 partitions, got %d", info.Count)
+	}
+	if len(info.Columns) != 1 || info.Columns[0] != "name" {
+		t.Fatalf("expect partition column [name], got %v", info.Columns)
+	}
+}
+
+func TestParsePartitionClauseUnpartitioned(t *testing.T) {
+	info, err := ParsePartitionClause("CREATE TABLE `t`(`value` int64 NOT NULL) ENGINE = Analytic")
+	if err != nil {
+		t.Fatalf("ParsePartitionClause: %v", err)
+	}
+	if info != nil {
+		t.Fatalf("expect nil PartitionInfo for an unpartitioned table, got %+v", info)
+	}
+}
+
+func TestComputePartitionIsStableAndInRange(t *testing.T) {
+	info := &PartitionInfo{Type: PartitionTypeKey, Columns: []string{"name"}, Count: 4}
+
+	p, err := NewPointBuilder("t").SetTimestamp(1).
+		AddTag("name", NewStringValue("tag-0")).
+		AddField("value", NewInt64Value(0)).
+		Build()
+	if err != nil {
+		t.Fatalf("build point: %v", err)
+	}
+
+	idx1, err := computePartition(info, p)
+	if err != nil {
+		t.Fatalf("computePartition: %v", err)
+	}
+	if idx1 < 0 || idx1 >= info.Count {
+		t.Fatalf("partition index %d out of range [0,%d)", idx1, info.Count)
+	}
+
+	idx2, err := computePartition(info, p)
+	if err != nil {
+		t.Fatalf("computePartition: %v", err)
+	}
+	if idx1 != idx2 {
+		t.Fatalf("expect the same point to always route to the same partition, got %d and %d", idx1, idx2)
+	}
+}
+
+// partitionedTransport fans writes out across a fixed partition count and
+// reports a CREATE TABLE statement with a PARTITION BY KEY clause, like a
+// real HoraeDB cluster would for `partitionTable`.
+type partitionedTransport struct {
+	partitionCount int
+	writes         map[int]int
+}
+
+func (p *partitionedTransport) WritePartition(_ context.Context, _ string, partition int, points []Point) (PartitionResult, error) {
+	if p.writes == nil {
+		p.writes = make(map[int]int)
+	}
+	p.writes[partition] += len(points)
+	return PartitionResult{Success: uint32(len(points))}, nil
+}
+
+func (p *partitionedTransport) Query(_ context.Context, _ SQLQueryRequest) (SQLQueryResponse, error) {
+	return SQLQueryResponse{}, nil
+}
+
+func (p *partitionedTransport) FetchSchema(_ context.Context, table string) (TableSchema, error) {
+	return TableSchema{
+		Table:          table,
+		CreateTableSQL: "CREATE TABLE `" + table + "`(`name`string TAG) PARTITION BY KEY(name) PARTITIONS " + strconv.Itoa(p.partitionCount) + " ENGINE = Analytic",
+	}, nil
+}
+
+func TestWriteFansOutAcrossPartitionsAndAggregatesPerPartitionResults(t *testing.T) {
+	transport := &partitionedTransport{partitionCount: 4}
+	c := NewClient(transport)
+
+	points := make([]Point, 0, 40)
+	for i := 0; i < 40; i++ {
+		p, err := NewPointBuilder("partition_table").SetTimestamp(1).
+			AddTag("name", NewStringValue(strconv.Itoa(i))).
+			AddField("value", NewInt64Value(int64(i))).
+			Build()
+		if err != nil {
+			t.Fatalf("build point: %v", err)
+		}
+		points = append(points, p)
+	}
+
+	resp, err := c.Write(context.Background(), WriteRequest{Points: points})
+	if err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if resp.Success != 40 {
+		t.Fatalf("expect 40 successes, got %+v", resp)
+	}
+	if len(resp.PerPartition) <= 1 {
+		t.Fatalf("expect points spread across multiple partitions, got %+v", resp.PerPartition)
+	}
+
+	var total uint32
+	for _, r := range resp.PerPartition {
+		total += r.Success
+	}
+	if total != 40 {
+		t.Fatalf("expect PerPartition successes to sum to 40, got %d", total)
+	}
+}
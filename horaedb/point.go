@@ -0,0 +1,88 @@
+package horaedb
+
+import "fmt"
+
+// Point is a single row to be written to a table: a timestamp, a set of
+// tag values and a set of field values. Points are immutable once built by
+// PointBuilder.
+type Point struct {
+	table     string
+	timestamp int64
+	tags      map[string]Value
+	fields    map[string]Value
+}
+
+// Table returns the table this point belongs to.
+func (p Point) Table() string { return p.table }
+
+// Timestamp returns the point's timestamp, in milliseconds since the epoch.
+func (p Point) Timestamp() int64 { return p.timestamp }
+
+// Tags returns the point's tag values, keyed by tag name.
+func (p Point) Tags() map[string]Value { return p.tags }
+
+// Fields returns the point's field values, keyed by field name.
+func (p Point) Fields() map[string]Value { return p.fields }
+
+// PartitionKey returns the value of the named column, looking it up first
+// among tags and then fields. It is used to route a point to the
+// partition responsible for it.
+func (p Point) PartitionKey(column string) (Value, bool) {
+	if v, ok := p.tags[column]; ok {
+		return v, true
+	}
+	if v, ok := p.fields[column]; ok {
+		return v, true
+	}
+	return nil, false
+}
+
+// PointBuilder incrementally builds a Point for a single table.
+type PointBuilder struct {
+	point Point
+	hasTS bool
+}
+
+// NewPointBuilder starts building a Point for the given table.
+func NewPointBuilder(table string) *PointBuilder {
+	return &PointBuilder{
+		point: Point{
+			table:  table,
+			tags:   make(map[string]Value),
+			fields: make(map[string]Value),
+		},
+	}
+}
+
+// SetTimestamp sets the point's timestamp, in milliseconds since the epoch.
+func (b *PointBuilder) SetTimestamp(ts int64) *PointBuilder {
+	b.point.timestamp = ts
+	b.hasTS = true
+	return b
+}
+
+// AddTag adds a tag column value to the point.
+func (b *PointBuilder) AddTag(name string, v Value) *PointBuilder {
+	b.point.tags[name] = v
+	return b
+}
+
+// AddField adds a field column value to the point.
+func (b *PointBuilder) AddField(name string, v Value) *PointBuilder {
+	b.point.fields[name] = v
+	return b
+}
+
+// Build validates and returns the finished Point.
+func (b *PointBuilder) Build() (Point, error) {
+	if b.point.table == "" {
+		return Point{}, fmt.Errorf("horaedb: point has no table")
+	}
+	if !b.hasTS {
+		return Point{}, fmt.Errorf("horaedb: point for table %q has no timestamp", b.point.table)
+	}
+	if len(b.point.fields) == 0 {
+		return Point{}, fmt.Errorf("horaedb: point for table %q has no fields", b.point.table)
+	}
+	return b.point, nil
+}
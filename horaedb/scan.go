@@ -0,0 +1,250 @@
+package horaedb
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Scan maps row's columns onto the fields of dst, a pointer to a struct,
+// using `horaedb:"column[,role]"` struct tags to match column names (the
+// optional role, e.g. "tag" or "timestamp", documents the column's kind
+// but is not required for matching). An empty or omitted column name
+// defaults to the field's own name, lowercased. Columns with no matching
+// tagged field, and tagged fields with no matching column, are silently
+// skipped, so callers can select more or fewer columns than a struct
+// declares.
+//
+// A SQL NULL coerces to the field's zero value if the field is a plain
+// Go type, to nil if the field is a pointer, and to an invalid/zero
+// value if the field is one of the database/sql Null* wrapper types.
+func Scan(row Row, dst interface{}) error {
+	destVal := reflect.ValueOf(dst)
+	if destVal.Kind() != reflect.Ptr || destVal.IsNil() || destVal.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("horaedb: Scan destination must be a non-nil pointer to a struct")
+	}
+	structVal := destVal.Elem()
+	structType := structVal.Type()
+
+	columns := row.ColumnNames()
+	values := row.Columns()
+	colIndex := make(map[string]int, len(columns))
+	for i, name := range columns {
+		colIndex[name] = i
+	}
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tag, ok := field.Tag.Lookup("horaedb")
+		if !ok {
+			continue
+		}
+
+		name, _ := parseScanTag(tag)
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		idx, ok := colIndex[name]
+		if !ok {
+			continue
+		}
+
+		if err := assignValue(structVal.Field(i), values[idx]); err != nil {
+			return fmt.Errorf("horaedb: column %q into field %q: %w", name, field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// ScanAll maps every row in resp onto a new element of a fresh slice and
+// stores that slice in dst, a pointer to a slice of struct, using the
+// same struct-tag rules as Scan. Any elements dst held before are
+// replaced, not kept.
+func (resp SQLQueryResponse) ScanAll(dst interface{}) error {
+	destVal := reflect.ValueOf(dst)
+	if destVal.Kind() != reflect.Ptr || destVal.IsNil() || destVal.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("horaedb: ScanAll destination must be a non-nil pointer to a slice")
+	}
+	sliceVal := destVal.Elem()
+	elemType := sliceVal.Type().Elem()
+
+	out := reflect.MakeSlice(sliceVal.Type(), 0, len(resp.Rows))
+	for _, row := range resp.Rows {
+		elemPtr := reflect.New(elemType)
+		if err := Scan(row, elemPtr.Interface()); err != nil {
+			return err
+		}
+		out = reflect.Append(out, elemPtr.Elem())
+	}
+
+	sliceVal.Set(out)
+	return nil
+}
+
+func parseScanTag(tag string) (name, role string) {
+	parts := strings.SplitN(tag, ",", 2)
+	name = parts[0]
+	if len(parts) == 2 {
+		role = parts[1]
+	}
+	return name, role
+}
+
+func assignValue(field reflect.Value, val Value) error {
+	if val == nil || val.Kind() == KindNull {
+		return assignNull(field)
+	}
+
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		return assignValue(field.Elem(), val)
+	}
+
+	if ok, err := assignSQLNull(field, val); ok {
+		return err
+	}
+
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := coerceInt64(val)
+		if err != nil {
+			return err
+		}
+		field.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := coerceUint64(val)
+		if err != nil {
+			return err
+		}
+		field.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := coerceFloat64(val)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	case reflect.String:
+		s, ok := val.String()
+		if !ok {
+			return fmt.Errorf("cannot assign %s into a string field", kindName(val.Kind()))
+		}
+		field.SetString(s)
+	case reflect.Bool:
+		b, ok := val.Bool()
+		if !ok {
+			return fmt.Errorf("cannot assign %s into a bool field", kindName(val.Kind()))
+		}
+		field.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported Scan destination kind %s", field.Kind())
+	}
+
+	return nil
+}
+
+func assignNull(field reflect.Value) error {
+	field.Set(reflect.Zero(field.Type()))
+	return nil
+}
+
+// assignSQLNull handles the database/sql Null* wrapper types as Scan
+// destinations. ok is false if field is not one of these types, in which
+// case the caller falls through to the plain-Go-type assignment.
+func assignSQLNull(field reflect.Value, val Value) (ok bool, err error) {
+	if !field.CanAddr() {
+		return false, nil
+	}
+
+	switch ptr := field.Addr().Interface().(type) {
+	case *sql.NullString:
+		s, valid := val.String()
+		*ptr = sql.NullString{String: s, Valid: valid}
+		return true, nil
+	case *sql.NullInt64:
+		i, valid := val.Int64()
+		*ptr = sql.NullInt64{Int64: i, Valid: valid}
+		return true, nil
+	case *sql.NullFloat64:
+		f, valid := val.Float64()
+		*ptr = sql.NullFloat64{Float64: f, Valid: valid}
+		return true, nil
+	case *sql.NullBool:
+		b, valid := val.Bool()
+		*ptr = sql.NullBool{Bool: b, Valid: valid}
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+func coerceInt64(val Value) (int64, error) {
+	switch val.Kind() {
+	case KindInt64:
+		i, _ := val.Int64()
+		return i, nil
+	case KindUint64:
+		u, _ := val.Uint64()
+		return int64(u), nil
+	case KindFloat64:
+		f, _ := val.Float64()
+		return int64(f), nil
+	default:
+		return 0, fmt.Errorf("cannot assign %s into an integer field", kindName(val.Kind()))
+	}
+}
+
+func coerceUint64(val Value) (uint64, error) {
+	switch val.Kind() {
+	case KindUint64:
+		u, _ := val.Uint64()
+		return u, nil
+	case KindInt64:
+		i, _ := val.Int64()
+		return uint64(i), nil
+	case KindFloat64:
+		f, _ := val.Float64()
+		return uint64(f), nil
+	default:
+		return 0, fmt.Errorf("cannot assign %s into an unsigned integer field", kindName(val.Kind()))
+	}
+}
+
+func coerceFloat64(val Value) (float64, error) {
+	switch val.Kind() {
+	case KindFloat64:
+		f, _ := val.Float64()
+		return f, nil
+	case KindInt64:
+		i, _ := val.Int64()
+		return float64(i), nil
+	case KindUint64:
+		u, _ := val.Uint64()
+		return float64(u), nil
+	default:
+		return 0, fmt.Errorf("cannot assign %s into a float field", kindName(val.Kind()))
+	}
+}
+
+func kindName(k Kind) string {
+	switch k {
+	case KindInt64:
+		return "an int64"
+	case KindUint64:
+		return "a uint64"
+	case KindFloat64:
+		return "a float64"
+	case KindString:
+		return "a string"
+	case KindBool:
+		return "a bool"
+	case KindNull:
+		return "NULL"
+	default:
+		return "an unknown value"
+	}
+}
@@ -0,0 +1,87 @@
+package horaedb
+
+import (
+	"database/sql"
+	"testing"
+)
+
+type scanRow struct {
+	T     int64   `horaedb:"t,timestamp"`
+	Name  string  `horaedb:"name,tag"`
+	Value int64   `horaedb:"value"`
+	BTag  *string `horaedb:"btag,tag"`
+}
+
+func TestScanHandlesNullPointerField(t *testing.T) {
+	row := NewRow(
+		[]string{"t", "name", "value", "btag"},
+		[]Value{NewInt64Value(1), NewStringValue("tag-0"), NewInt64Value(5), NewNullValue()},
+	)
+
+	var dst scanRow
+	if err := Scan(row, &dst); err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+
+	if dst.T != 1 || dst.Name != "tag-0" || dst.Value != 5 {
+		t.Fatalf("unexpected scanned row: %+v", dst)
+	}
+	if dst.BTag != nil {
+		t.Fatalf("expect NULL btag to scan to a nil pointer, got %q", *dst.BTag)
+	}
+}
+
+func TestScanPopulatesPointerField(t *testing.T) {
+	row := NewRow(
+		[]string{"t", "name", "value", "btag"},
+		[]Value{NewInt64Value(1), NewStringValue("tag-0"), NewInt64Value(5), NewStringValue("sstag")},
+	)
+
+	var dst scanRow
+	if err := Scan(row, &dst); err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if dst.BTag == nil || *dst.BTag != "sstag" {
+		t.Fatalf("expect btag to scan to \"sstag\", got %v", dst.BTag)
+	}
+}
+
+type sqlNullRow struct {
+	Name string         `horaedb:"name"`
+	BTag sql.NullString `horaedb:"btag"`
+}
+
+func TestScanHandlesNullSQLNullString(t *testing.T) {
+	row := NewRow([]string{"name", "btag"}, []Value{NewStringValue("tag-0"), NewNullValue()})
+
+	var dst sqlNullRow
+	if err := Scan(row, &dst); err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if dst.BTag.Valid {
+		t.Fatalf("expect NULL to scan to an invalid sql.NullString, got %+v", dst.BTag)
+	}
+}
+
+func TestScanAllMapsEveryRow(t *testing.T) {
+	resp := SQLQueryResponse{
+		Rows: []Row{
+			NewRow([]string{"t", "name", "value", "btag"}, []Value{NewInt64Value(1), NewStringValue("tag-0"), NewInt64Value(0), NewNullValue()}),
+			NewRow([]string{"t", "name", "value", "btag"}, []Value{NewInt64Value(1), NewStringValue("tag-1"), NewInt64Value(1), NewStringValue("sstag")}),
+		},
+	}
+
+	var rows []scanRow
+	if err := resp.ScanAll(&rows); err != nil {
+		t.Fatalf("ScanAll: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expect 2 rows, got %d", len(rows))
+	}
+	if rows[0].BTag != nil {
+		t.Fatalf("expect row 0 btag to be nil, got %q", *rows[0].BTag)
+	}
+	if rows[1].BTag == nil || *rows[1].BTag != "sstag" {
+		t.Fatalf("expect row 1 btag to be sstag, got %v", rows[1].BTag)
+	}
+}
@@ -0,0 +1,32 @@
+package horaedb
+
+// SQLQueryRequest is a SQL query against one or more tables.
+type SQLQueryRequest struct {
+	Tables []string
+	SQL    string
+}
+
+// SQLQueryResponse holds the rows returned by a SQL query.
+type SQLQueryResponse struct {
+	Rows []Row
+}
+
+// Row is a single result row, with columns addressable by position or by
+// name.
+type Row struct {
+	names  []string
+	values []Value
+}
+
+// NewRow builds a Row from parallel column-name and column-value slices,
+// as returned by a Transport implementation.
+func NewRow(names []string, values []Value) Row {
+	return Row{names: names, values: values}
+}
+
+// Columns returns the row's values in column order.
+func (r Row) Columns() []Value { return r.values }
+
+// ColumnNames returns the row's column names, in the same order as
+// Columns.
+func (r Row) ColumnNames() []string { return r.names }
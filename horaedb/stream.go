@@ -0,0 +1,249 @@
+package horaedb
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// PointStream is a long-lived handle for writing a large number of points
+// to a single table without materializing them all in memory first, akin
+// to pq.CopyIn for Postgres. Points are buffered and auto-flushed once
+// the batch crosses a size or time threshold; Send blocks once too many
+// bytes are in flight, so a fast producer cannot run the client out of
+// memory.
+type PointStream interface {
+	// Send buffers p, then flushes the current batch if adding p brought
+	// it to the size or byte threshold. It blocks while the amount of
+	// data in flight to the server exceeds the stream's high-water mark.
+	Send(p Point) error
+
+	// Flush writes the current batch immediately, even if it hasn't
+	// crossed the auto-flush thresholds yet, and reports how many of
+	// *that* batch's points succeeded — not the stream's lifetime total,
+	// since Send can have already auto-flushed earlier batches on the
+	// caller's behalf. An empty batch is a no-op. Use Stats for the
+	// running total across every flush, auto or explicit.
+	Flush() (WriteResponse, error)
+
+	// Stats reports the cumulative WriteResponse across every flush the
+	// stream has performed so far, whether triggered by Send's
+	// auto-flush, an explicit Flush, the background flush timer, or
+	// Close.
+	Stats() WriteResponse
+
+	// Close flushes any buffered points and stops the stream's
+	// background flush timer. The stream must not be used afterwards.
+	// Use Stats after Close for the stream's lifetime totals.
+	Close() error
+}
+
+type streamOptions struct {
+	maxBatchPoints     int
+	maxBatchBytes      int64
+	flushInterval      time.Duration
+	highWaterMarkBytes int64
+}
+
+func defaultStreamOptions() streamOptions {
+	return streamOptions{
+		maxBatchPoints:     500,
+		maxBatchBytes:      4 << 20, // 4MiB
+		flushInterval:      time.Second,
+		highWaterMarkBytes: 32 << 20, // 32MiB
+	}
+}
+
+// StreamOption configures a PointStream returned by Client.WriteStream.
+type StreamOption func(*streamOptions)
+
+// WithMaxBatchPoints sets how many points Send buffers before
+// auto-flushing.
+func WithMaxBatchPoints(n int) StreamOption {
+	return func(o *streamOptions) { o.maxBatchPoints = n }
+}
+
+// WithMaxBatchBytes sets the approximate buffered-byte threshold at which
+// Send auto-flushes.
+func WithMaxBatchBytes(n int64) StreamOption {
+	return func(o *streamOptions) { o.maxBatchBytes = n }
+}
+
+// WithFlushInterval sets how often the stream flushes a non-empty batch
+// in the background, regardless of its size.
+func WithFlushInterval(d time.Duration) StreamOption {
+	return func(o *streamOptions) { o.flushInterval = d }
+}
+
+// WithHighWaterMarkBytes sets how many bytes may be in flight to the
+// server (buffered in batches not yet acknowledged) before Send blocks.
+func WithHighWaterMarkBytes(n int64) StreamOption {
+	return func(o *streamOptions) { o.highWaterMarkBytes = n }
+}
+
+// WriteStream opens a PointStream for bulk, COPY-style writes to table.
+func (c *client) WriteStream(ctx context.Context, table string, opts ...StreamOption) (PointStream, error) {
+	o := defaultStreamOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	s := &pointStream{
+		c:          c,
+		ctx:        ctx,
+		table:      table,
+		opts:       o,
+		stopTicker: make(chan struct{}),
+	}
+	s.cond = sync.NewCond(&s.mu)
+
+	go s.runFlushTimer()
+
+	return s, nil
+}
+
+type pointStream struct {
+	c     *client
+	ctx   context.Context
+	table string
+	opts  streamOptions
+
+	mu            sync.Mutex
+	cond          *sync.Cond
+	batch         []Point
+	batchBytes    int64
+	inFlightBytes int64
+	closed        bool
+	stats         WriteResponse
+
+	stopTicker chan struct{}
+}
+
+func (s *pointStream) Send(p Point) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return fmt.Errorf("horaedb: stream for table %q is closed", s.table)
+	}
+
+	for s.inFlightBytes > s.opts.highWaterMarkBytes {
+		s.cond.Wait()
+		if s.closed {
+			s.mu.Unlock()
+			return fmt.Errorf("horaedb: stream for table %q is closed", s.table)
+		}
+	}
+
+	s.batch = append(s.batch, p)
+	s.batchBytes += estimatePointBytes(p)
+	shouldFlush := len(s.batch) >= s.opts.maxBatchPoints || s.batchBytes >= s.opts.maxBatchBytes
+	s.mu.Unlock()
+
+	if shouldFlush {
+		_, err := s.Flush()
+		return err
+	}
+	return nil
+}
+
+func (s *pointStream) Flush() (WriteResponse, error) {
+	s.mu.Lock()
+	if len(s.batch) == 0 {
+		s.mu.Unlock()
+		return WriteResponse{}, nil
+	}
+
+	batch := s.batch
+	batchBytes := s.batchBytes
+	s.batch = nil
+	s.batchBytes = 0
+	s.inFlightBytes += batchBytes
+	s.mu.Unlock()
+
+	resp, err := s.c.writeOnce(s.ctx, WriteRequest{Points: batch})
+
+	s.mu.Lock()
+	s.inFlightBytes -= batchBytes
+	if err == nil {
+		s.addStatsLocked(resp)
+	}
+	s.cond.Broadcast()
+	s.mu.Unlock()
+
+	return resp, err
+}
+
+// addStatsLocked folds a single flush's WriteResponse into the stream's
+// running total. Callers must hold s.mu.
+func (s *pointStream) addStatsLocked(resp WriteResponse) {
+	s.stats.Success += resp.Success
+	s.stats.Failed += resp.Failed
+
+	if len(resp.PerPartition) == 0 {
+		return
+	}
+	if s.stats.PerPartition == nil {
+		s.stats.PerPartition = make(map[int]PartitionResult, len(resp.PerPartition))
+	}
+	for partition, result := range resp.PerPartition {
+		total := s.stats.PerPartition[partition]
+		total.Success += result.Success
+		total.Failed += result.Failed
+		s.stats.PerPartition[partition] = total
+	}
+}
+
+// Stats reports the stream's cumulative WriteResponse so far.
+func (s *pointStream) Stats() WriteResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
+
+func (s *pointStream) Close() error {
+	close(s.stopTicker)
+
+	_, err := s.Flush()
+
+	s.mu.Lock()
+	s.closed = true
+	s.cond.Broadcast()
+	s.mu.Unlock()
+
+	return err
+}
+
+func (s *pointStream) runFlushTimer() {
+	ticker := time.NewTicker(s.opts.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			_, _ = s.Flush()
+		case <-s.stopTicker:
+			return
+		}
+	}
+}
+
+// estimatePointBytes approximates a point's wire size well enough to
+// drive batching decisions, without needing to actually serialize it.
+func estimatePointBytes(p Point) int64 {
+	n := int64(len(p.Table()))
+	for k, v := range p.Tags() {
+		n += int64(len(k)) + valueBytes(v)
+	}
+	for k, v := range p.Fields() {
+		n += int64(len(k)) + valueBytes(v)
+	}
+	return n
+}
+
+func valueBytes(v Value) int64 {
+	if s, ok := v.String(); ok {
+		return int64(len(s))
+	}
+	return 8
+}
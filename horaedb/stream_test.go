@@ -0,0 +1,94 @@
+package horaedb
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestStreamAutoFlushesOnBatchSize(t *testing.T) {
+	transport := &fakeTransport{}
+	c := NewClient(transport)
+
+	stream, err := c.WriteStream(context.Background(), "t",
+		WithMaxBatchPoints(3),
+		WithFlushInterval(time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("WriteStream: %v", err)
+	}
+	defer stream.Close()
+
+	for i := 0; i < 3; i++ {
+		if err := stream.Send(newTestPoint(t)); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+	}
+
+	// The 3rd Send should have crossed maxBatchPoints and flushed already.
+	if transport.writesSeen != 1 {
+		t.Fatalf("expect 1 auto-flush after 3 points, saw %d writes", transport.writesSeen)
+	}
+}
+
+func TestStreamFlushAndClose(t *testing.T) {
+	transport := &fakeTransport{}
+	c := NewClient(transport)
+
+	stream, err := c.WriteStream(context.Background(), "t", WithFlushInterval(time.Hour))
+	if err != nil {
+		t.Fatalf("WriteStream: %v", err)
+	}
+
+	if err := stream.Send(newTestPoint(t)); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	resp, err := stream.Flush()
+	if err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if resp.Success != 1 {
+		t.Fatalf("expect 1 success, got %+v", resp)
+	}
+
+	// Close on an already-flushed, empty stream should be a no-op write.
+	if err := stream.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if transport.writesSeen != 1 {
+		t.Fatalf("expect exactly 1 write total, saw %d", transport.writesSeen)
+	}
+}
+
+func TestStreamStatsAccumulateAcrossAutoFlushes(t *testing.T) {
+	transport := &fakeTransport{}
+	c := NewClient(transport)
+
+	stream, err := c.WriteStream(context.Background(), "t",
+		WithMaxBatchPoints(3),
+		WithFlushInterval(time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("WriteStream: %v", err)
+	}
+
+	// 7 points with a batch size of 3 auto-flushes twice (at 3 and 6),
+	// leaving 1 point for the final explicit Flush.
+	for i := 0; i < 7; i++ {
+		if err := stream.Send(newTestPoint(t)); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+	}
+
+	if _, err := stream.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if err := stream.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if stats := stream.Stats(); stats.Success != 7 {
+		t.Fatalf("expect cumulative stats of 7 successes across all flushes, got %+v", stats)
+	}
+}
@@ -0,0 +1,40 @@
+package horaedb
+
+import "context"
+
+// TableSchema is the subset of a table's metadata the client needs to
+// route and retry writes: its columns and, for partitioned tables, how
+// rows are distributed across partitions.
+type TableSchema struct {
+	Table   string
+	Columns []string
+
+	// Partition is non-nil when the table is partitioned. A Transport
+	// implementation may populate this directly if the server already
+	// reports structured partition metadata; otherwise it can leave this
+	// nil and set CreateTableSQL, and Client will derive it by parsing
+	// the PARTITION BY clause itself (see ParsePartitionClause).
+	Partition *PartitionInfo
+
+	// CreateTableSQL is the table's CREATE TABLE statement, as returned
+	// by e.g. `SHOW CREATE TABLE`. It is only consulted when Partition
+	// is nil.
+	CreateTableSQL string
+}
+
+// Transport is the network boundary between Client and the HoraeDB
+// cluster. Production code talks to the server over gRPC; tests and the
+// in-process examples in this repo can supply a fake implementation.
+type Transport interface {
+	// WritePartition writes points already routed to a single partition
+	// (partition is 0 for unpartitioned tables) and reports how many
+	// succeeded or failed.
+	WritePartition(ctx context.Context, table string, partition int, points []Point) (PartitionResult, error)
+
+	// Query runs a SQL query and returns its result rows.
+	Query(ctx context.Context, req SQLQueryRequest) (SQLQueryResponse, error)
+
+	// FetchSchema returns the current schema for table, used to populate
+	// and refresh Client's schema cache.
+	FetchSchema(ctx context.Context, table string) (TableSchema, error)
+}
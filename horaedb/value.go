@@ -0,0 +1,87 @@
+package horaedb
+
+// Kind identifies the Go type carried by a Value.
+type Kind int
+
+const (
+	KindInt64 Kind = iota
+	KindUint64
+	KindFloat64
+	KindString
+	KindBool
+	KindNull
+)
+
+// Value is a single typed column/field/tag value exchanged with HoraeDB.
+type Value interface {
+	Kind() Kind
+	Int64() (int64, bool)
+	Uint64() (uint64, bool)
+	Float64() (float64, bool)
+	String() (string, bool)
+	Bool() (bool, bool)
+}
+
+type value struct {
+	kind Kind
+	i    int64
+	u    uint64
+	f    float64
+	s    string
+	b    bool
+}
+
+func (v *value) Kind() Kind { return v.kind }
+
+func (v *value) Int64() (int64, bool) {
+	if v.kind != KindInt64 {
+		return 0, false
+	}
+	return v.i, true
+}
+
+func (v *value) Uint64() (uint64, bool) {
+	if v.kind != KindUint64 {
+		return 0, false
+	}
+	return v.u, true
+}
+
+func (v *value) Float64() (float64, bool) {
+	if v.kind != KindFloat64 {
+		return 0, false
+	}
+	return v.f, true
+}
+
+func (v *value) String() (string, bool) {
+	if v.kind != KindString {
+		return "", false
+	}
+	return v.s, true
+}
+
+func (v *value) Bool() (bool, bool) {
+	if v.kind != KindBool {
+		return false, false
+	}
+	return v.b, true
+}
+
+// NewInt64Value builds a Value wrapping an int64.
+func NewInt64Value(i int64) Value { return &value{kind: KindInt64, i: i} }
+
+// NewUint64Value builds a Value wrapping a uint64.
+func NewUint64Value(u uint64) Value { return &value{kind: KindUint64, u: u} }
+
+// NewFloat64Value builds a Value wrapping a float64.
+func NewFloat64Value(f float64) Value { return &value{kind: KindFloat64, f: f} }
+
+// NewStringValue builds a Value wrapping a string.
+func NewStringValue(s string) Value { return &value{kind: KindString, s: s} }
+
+// NewBoolValue builds a Value wrapping a bool.
+func NewBoolValue(b bool) Value { return &value{kind: KindBool, b: b} }
+
+// NewNullValue builds a Value representing SQL NULL.
+func NewNullValue() Value { return &value{kind: KindNull} }
@@ -36,13 +36,9 @@ func checkPartitionTableAddColumn(ctx context.Context, client horaedb.Client) er
 
 	ts := currentMS()
 
-	// First write will fail, because the schema is not updated yet.
-	// Currently, horaedb.will update the schema when write failed.
-	err = writePartitionTableNewField(ctx, client, ts, fieldName)
-	if err == nil {
-		panic("first write should fail")
-	}
-
+	// The schema is not updated on the client yet, but client.Write now retries
+	// transparently on a schema-mismatch error after refreshing the cached
+	// route/schema, so a single write is enough.
 	if err := writePartitionTableNewField(ctx, client, ts, fieldName); err != nil {
 		return err
 	}
@@ -52,13 +48,6 @@ func checkPartitionTableAddColumn(ctx context.Context, client horaedb.Client) er
 		return err
 	}
 
-	// First write will fail, because the schema is not updated yet.
-	// Currently, write failed will update the schema.
-	err = writePartitionTableNewTag(ctx, client, ts, tagName)
-	if err == nil {
-		panic("first write should fail")
-	}
-
 	if err := writePartitionTableNewTag(ctx, client, ts, tagName); err != nil {
 		return err
 	}
@@ -67,7 +56,7 @@ func checkPartitionTableAddColumn(ctx context.Context, client horaedb.Client) er
 		return err
 	}
 
-	return nil
+	return checkPartitionFanOut(ctx, client, ts)
 }
 
 func writePartitionTableNewField(ctx context.Context, client horaedb.Client, ts int64, fieldName string) error {
@@ -88,7 +77,8 @@ func writePartitionTableNewField(ctx context.Context, client horaedb.Client, ts
 	}
 
 	resp, err := client.Write(ctx, horaedb.WriteRequest{
-		Points: points,
+		Points:                    points,
+		AutoRetryOnSchemaMismatch: true,
 	})
 	if err != nil {
 		return err
@@ -119,7 +109,8 @@ func writePartitionTableNewTag(ctx context.Context, client horaedb.Client, ts in
 	}
 
 	resp, err := client.Write(ctx, horaedb.WriteRequest{
-		Points: points,
+		Points:                    points,
+		AutoRetryOnSchemaMismatch: true,
 	})
 	if err != nil {
 		return err
@@ -131,6 +122,17 @@ func writePartitionTableNewTag(ctx context.Context, client horaedb.Client, ts in
 	return nil
 }
 
+// partitionTableRow mirrors the columns selected by queryPartitionTable.
+// btag is a pointer because it is NULL for rows written before the
+// `btag` column existed.
+type partitionTableRow struct {
+	T     int64   `horaedb:"t,timestamp"`
+	Name  string  `horaedb:"name,tag"`
+	Value int64   `horaedb:"value"`
+	B     string  `horaedb:"b"`
+	BTag  *string `horaedb:"btag,tag"`
+}
+
 func queryPartitionTable(ctx context.Context, client horaedb.Client, ts int64, timestampName string) error {
 	sql := fmt.Sprintf("select t, name, value,%s,%s from %s where %s = %d order by name,%s", fieldName, tagName, partitionTable, timestampName, ts, tagName)
 
@@ -142,52 +144,39 @@ func queryPartitionTable(ctx context.Context, client horaedb.Client, ts int64, t
 		return err
 	}
 
-	if len(resp.Rows) != 4 {
-		return fmt.Errorf("expect 2 rows, current: %+v", len(resp.Rows))
+	var rows []partitionTableRow
+	if err := resp.ScanAll(&rows); err != nil {
+		return err
 	}
 
-	row0 := []horaedb.Value{
-		horaedb.NewInt64Value(ts),
-		horaedb.NewStringValue("tag-0"),
-		horaedb.NewInt64Value(0),
-		horaedb.NewStringValue("ss"),
-		horaedb.NewStringValue("sstag"),
+	if len(rows) != 4 {
+		return fmt.Errorf("expect 4 rows, current: %+v", len(rows))
 	}
 
-	row1 := []horaedb.Value{
-		horaedb.NewInt64Value(ts),
-		horaedb.NewStringValue("tag-0"),
-		horaedb.NewInt64Value(0),
-		horaedb.NewStringValue("ss"),
+	sstag := "sstag"
+	expected := []partitionTableRow{
+		{T: ts, Name: "tag-0", Value: 0, B: "ss", BTag: &sstag},
+		{T: ts, Name: "tag-0", Value: 0, B: "ss"},
+		{T: ts, Name: "tag-1", Value: 1, B: "ss", BTag: &sstag},
+		{T: ts, Name: "tag-1", Value: 1, B: "ss"},
 	}
 
-	row2 := []horaedb.Value{
-		horaedb.NewInt64Value(ts),
-		horaedb.NewStringValue("tag-1"),
-		horaedb.NewInt64Value(1),
-		horaedb.NewStringValue("ss"),
-		horaedb.NewStringValue("sstag"),
+	for i := range expected {
+		if err := ensurePartitionTableRow(expected[i], rows[i]); err != nil {
+			return err
+		}
 	}
 
-	row3 := []horaedb.Value{
-		horaedb.NewInt64Value(ts),
-		horaedb.NewStringValue("tag-1"),
-		horaedb.NewInt64Value(1),
-		horaedb.NewStringValue("ss"),
-	}
+	return nil
+}
 
-	if err := ensureRow(row0,
-		resp.Rows[0].Columns()); err != nil {
-		return err
-	}
-	if err := ensureRow(row1,
-		resp.Rows[1].Columns()); err != nil {
-		return err
-	}
-	if err := ensureRow(row2,
-		resp.Rows[2].Columns()); err != nil {
-		return err
+func ensurePartitionTableRow(want, got partitionTableRow) error {
+	btagEqual := (want.BTag == nil && got.BTag == nil) ||
+		(want.BTag != nil && got.BTag != nil && *want.BTag == *got.BTag)
+
+	if want.T != got.T || want.Name != got.Name || want.Value != got.Value || want.B != got.B || !btagEqual {
+		return fmt.Errorf("row mismatch, want: %+v, got: %+v", want, got)
 	}
 
-	return ensureRow(row3, resp.Rows[3].Columns())
+	return nil
 }
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/apache/incubator-horaedb-client-go/horaedb"
+	"github.com/apache/incubator-horaedb-client-go/horaedb/lineproto"
+)
+
+const lineProtoTable = "line_proto_table"
+
+func checkLineProtocolIngest(ctx context.Context, client horaedb.Client) error {
+	err := dropTable(ctx, client, lineProtoTable)
+	if err != nil {
+		return err
+	}
+
+	_, err = ddl(ctx, client, lineProtoTable, fmt.Sprintf(
+		"CREATE TABLE `%s`("+
+			"`name` string TAG,"+
+			"`value` int64 NOT NULL,"+
+			"`t` timestamp NOT NULL,"+
+			"TIMESTAMP KEY(t)) ENGINE = Analytic", lineProtoTable))
+	if err != nil {
+		return err
+	}
+
+	ts := currentMS()
+
+	// Two rows of the table above, expressed the way Telegraf would emit them,
+	// with a tag value containing an escaped comma.
+	lines := strings.Join([]string{
+		fmt.Sprintf("%s,name=tag\\,0 value=0i %d", lineProtoTable, ts*1e6),
+		fmt.Sprintf("%s,name=tag-1 value=1i %d", lineProtoTable, ts*1e6),
+	}, "\n")
+
+	resp, err := lineproto.Write(ctx, client, strings.NewReader(lines), lineproto.WithPrecision(lineproto.PrecisionNanosecond))
+	if err != nil {
+		return err
+	}
+
+	if resp.Success != 2 {
+		return fmt.Errorf("write line protocol failed, resp: %+v", resp)
+	}
+
+	return queryLineProtoTable(ctx, client, ts)
+}
+
+func queryLineProtoTable(ctx context.Context, client horaedb.Client, ts int64) error {
+	sql := fmt.Sprintf("select t, name, value from %s where t = %d order by name", lineProtoTable, ts)
+
+	resp, err := client.SQLQuery(ctx, horaedb.SQLQueryRequest{
+		Tables: []string{lineProtoTable},
+		SQL:    sql,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(resp.Rows) != 2 {
+		return fmt.Errorf("expect 2 rows, current: %+v", len(resp.Rows))
+	}
+
+	row0 := []horaedb.Value{
+		horaedb.NewInt64Value(ts),
+		horaedb.NewStringValue("tag,0"),
+		horaedb.NewInt64Value(0),
+	}
+
+	row1 := []horaedb.Value{
+		horaedb.NewInt64Value(ts),
+		horaedb.NewStringValue("tag-1"),
+		horaedb.NewInt64Value(1),
+	}
+
+	if err := ensureRow(row0, resp.Rows[0].Columns()); err != nil {
+		return err
+	}
+
+	return ensureRow(row1, resp.Rows[1].Columns())
+}
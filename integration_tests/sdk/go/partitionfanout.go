@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/incubator-horaedb-client-go/horaedb"
+)
+
+const partitionFanOutRowCount = 40
+
+func checkPartitionFanOut(ctx context.Context, client horaedb.Client, ts int64) error {
+	points := make([]horaedb.Point, 0, partitionFanOutRowCount)
+	for i := 0; i < partitionFanOutRowCount; i++ {
+		point, err := horaedb.NewPointBuilder(partitionTable).
+			SetTimestamp(ts).
+			AddTag("name", horaedb.NewStringValue(fmt.Sprintf("fanout-%d", i))).
+			AddField("value", horaedb.NewInt64Value(int64(i))).
+			Build()
+		if err != nil {
+			return err
+		}
+		points = append(points, point)
+	}
+
+	resp, err := client.Write(ctx, horaedb.WriteRequest{
+		Points: points,
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp.Success != partitionFanOutRowCount {
+		return fmt.Errorf("fan-out write failed, resp: %+v", resp)
+	}
+
+	// `name` is the partition key, so a batch this size should have been
+	// routed to more than one of the table's 4 partitions.
+	if len(resp.PerPartition) <= 1 {
+		return fmt.Errorf("expect writes to be spread across multiple partitions, got: %+v", resp.PerPartition)
+	}
+
+	var total uint32
+	for partition, result := range resp.PerPartition {
+		if result.Failed != 0 {
+			return fmt.Errorf("partition %d reported failures: %+v", partition, result)
+		}
+		total += result.Success
+	}
+
+	if total != partitionFanOutRowCount {
+		return fmt.Errorf("expect %d rows across partitions, got %d", partitionFanOutRowCount, total)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/incubator-horaedb-client-go/horaedb"
+	"github.com/apache/incubator-horaedb-client-go/horaedb/migrate"
+)
+
+const migrationTable = "migration_table"
+
+func checkSchemaMigration(ctx context.Context, client horaedb.Client) error {
+	err := dropTable(ctx, client, migrationTable)
+	if err != nil {
+		return err
+	}
+
+	migrations := []migrate.Migration{
+		{
+			ID:          "202407260001_create_migration_table",
+			Description: "create the base table",
+			Up: func(ctx context.Context, client horaedb.Client) error {
+				_, err := ddl(ctx, client, migrationTable, fmt.Sprintf(
+					"CREATE TABLE `%s`("+
+						"`name` string TAG,"+
+						"`value` int64 NOT NULL,"+
+						"`t` timestamp NOT NULL,"+
+						"TIMESTAMP KEY(t)) ENGINE = Analytic", migrationTable))
+				return err
+			},
+			Down: func(ctx context.Context, client horaedb.Client) error {
+				return dropTable(ctx, client, migrationTable)
+			},
+		},
+		{
+			ID:          "202407260002_add_extra_column",
+			Description: "add the extra column used by the new write path",
+			Up:          migrate.AddColumn(migrationTable, "extra", "string", false),
+			Down: func(ctx context.Context, client horaedb.Client) error {
+				// HoraeDB does not support dropping a column, so there is
+				// nothing meaningful to revert here.
+				return nil
+			},
+		},
+	}
+
+	if err := migrate.Run(ctx, client, migrations); err != nil {
+		return err
+	}
+
+	ts := currentMS()
+	point, err := horaedb.NewPointBuilder(migrationTable).
+		SetTimestamp(ts).
+		AddTag("name", horaedb.NewStringValue("tag-0")).
+		AddField("value", horaedb.NewInt64Value(0)).
+		AddField("extra", horaedb.NewStringValue("ss")).
+		Build()
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Write(ctx, horaedb.WriteRequest{
+		Points: []horaedb.Point{point},
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp.Success != 1 {
+		return fmt.Errorf("write failed, resp: %+v", resp)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/incubator-horaedb-client-go/horaedb"
+)
+
+const streamTable = "stream_write_table"
+
+const streamWriteRowCount = 1000
+
+func checkStreamWrite(ctx context.Context, client horaedb.Client) error {
+	err := dropTable(ctx, client, streamTable)
+	if err != nil {
+		return err
+	}
+
+	_, err = ddl(ctx, client, streamTable, fmt.Sprintf(
+		"CREATE TABLE `%s`("+
+			"`name` string TAG,"+
+			"`value` int64 NOT NULL,"+
+			"`t` timestamp NOT NULL,"+
+			"TIMESTAMP KEY(t)) ENGINE = Analytic", streamTable))
+	if err != nil {
+		return err
+	}
+
+	ts := currentMS()
+
+	stream, err := client.WriteStream(ctx, streamTable)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < streamWriteRowCount; i++ {
+		point, err := horaedb.NewPointBuilder(streamTable).
+			SetTimestamp(ts).
+			AddTag("name", horaedb.NewStringValue(fmt.Sprintf("tag-%d", i))).
+			AddField("value", horaedb.NewInt64Value(int64(i))).
+			Build()
+		if err != nil {
+			_ = stream.Close()
+			return err
+		}
+
+		if err := stream.Send(point); err != nil {
+			_ = stream.Close()
+			return err
+		}
+	}
+
+	if _, err := stream.Flush(); err != nil {
+		_ = stream.Close()
+		return err
+	}
+
+	if err := stream.Close(); err != nil {
+		return err
+	}
+
+	if stats := stream.Stats(); stats.Success != streamWriteRowCount {
+		return fmt.Errorf("stream write failed, stats: %+v", stats)
+	}
+
+	sql := fmt.Sprintf("select count(*) as cnt from %s where t = %d", streamTable, ts)
+	queryResp, err := client.SQLQuery(ctx, horaedb.SQLQueryRequest{
+		Tables: []string{streamTable},
+		SQL:    sql,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(queryResp.Rows) != 1 {
+		return fmt.Errorf("expect 1 row, current: %+v", len(queryResp.Rows))
+	}
+
+	return ensureRow([]horaedb.Value{horaedb.NewInt64Value(streamWriteRowCount)}, queryResp.Rows[0].Columns())
+}